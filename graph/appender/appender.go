@@ -0,0 +1,71 @@
+package appender
+
+import (
+	"sync"
+
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+
+	"github.com/kiali/kiali/graph/tree"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// Appender mutates a namespace's service trees with additional graph
+// metadata (badges). Appenders are looked up by Name() from the registry and
+// ordered by DependsOn() so that, for example, an appender resolving the
+// backing workload can run before one that only knows how to badge it.
+type Appender interface {
+	// Name uniquely identifies the appender, e.g. for the "?appenders=" query param.
+	Name() string
+
+	// AppendGraph mutates trees in place for the given namespace. context carries
+	// data the pipeline already fetched so the appender doesn't hit the API again.
+	AppendGraph(trees *[]tree.ServiceNode, namespaceName string, context *AppenderContext) error
+
+	// DependsOn lists the Name() of appenders that must run, and complete, before
+	// this one. Appenders in the same dependency level are run concurrently.
+	DependsOn() []string
+}
+
+// AppenderContext holds the namespace-wide data the pipeline fetched once up
+// front, so that appenders read from it instead of each re-querying the API.
+type AppenderContext struct {
+	IstioDetails *kubernetes.IstioDetails
+	Workloads    *kubernetes.WorkloadList
+	Autoscalers  *autoscalingV1.HorizontalPodAutoscalerList
+}
+
+var registry = map[string]Appender{}
+
+// metadataMu guards n.Metadata writes across the whole package: the pipeline
+// runs every appender in a dependency level concurrently over the same
+// trees, so two appenders in the same level (e.g. the hpa and health
+// appenders, which both only DependsOn the istio appender) can badge the
+// same node at the same time. A plain map write from concurrent goroutines
+// is a fatal runtime error, not just a race, so every appender must go
+// through setMetadata/findOrAddChild instead of writing n.Metadata or n.Children
+// directly.
+var metadataMu sync.Mutex
+
+// setMetadata sets n.Metadata[key] under metadataMu, so appenders sharing a
+// dependency level can badge the same node without corrupting its map.
+func setMetadata(n *tree.ServiceNode, key, value string) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	n.Metadata[key] = value
+}
+
+// RegisterAppender adds an appender to the registry under its Name(). Appenders
+// register themselves from an init() in their own file.
+func RegisterAppender(a Appender) {
+	registry[a.Name()] = a
+}
+
+// DefaultAppenderNames returns every registered appender name. Used when the
+// "?appenders=" query param and the config.yaml appenders list are both empty.
+func DefaultAppenderNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,89 @@
+package appender
+
+import (
+	"strconv"
+
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+
+	"github.com/kiali/kiali/graph/tree"
+)
+
+const AutoscalerAppenderName = "hpa"
+
+func init() {
+	RegisterAppender(AutoscalerAppender{})
+}
+
+// AutoscalerAppender badges graph nodes with their HorizontalPodAutoscaler state,
+// so the graph can show autoscaling without a detail round-trip.
+type AutoscalerAppender struct{}
+
+func (a AutoscalerAppender) Name() string {
+	return AutoscalerAppenderName
+}
+
+// DependsOn the istio appender so n.Version and the backing workload are
+// already resolved by the time this one runs.
+func (a AutoscalerAppender) DependsOn() []string {
+	return []string{IstioAppenderName}
+}
+
+func (a AutoscalerAppender) AppendGraph(trees *[]tree.ServiceNode, namespaceName string, context *AppenderContext) error {
+	for i := range *trees {
+		addAutoscalerBadges(&(*trees)[i], context)
+	}
+	return nil
+}
+
+func addAutoscalerBadges(n *tree.ServiceNode, context *AppenderContext) {
+	applyAutoscaler(n, context)
+
+	for _, child := range n.Children {
+		addAutoscalerBadges(child, context)
+	}
+}
+
+func applyAutoscaler(n *tree.ServiceNode, context *AppenderContext) {
+	if context.Autoscalers == nil || len(context.Autoscalers.Items) == 0 {
+		return
+	}
+
+	workload := workloadForNode(n, context)
+	if workload == nil {
+		return
+	}
+
+	autoscaler := autoscalerForDeployment(context.Autoscalers, workload.Name)
+	if autoscaler == nil {
+		return
+	}
+
+	setMetadata(n, "hasAutoscaler", "true")
+	setMetadata(n, "autoscaler.minReplicas", strconv.Itoa(int(minReplicasOf(*autoscaler))))
+	setMetadata(n, "autoscaler.maxReplicas", strconv.Itoa(int(autoscaler.Spec.MaxReplicas)))
+	setMetadata(n, "autoscaler.currentReplicas", strconv.Itoa(int(autoscaler.Status.CurrentReplicas)))
+	setMetadata(n, "autoscaler.desiredReplicas", strconv.Itoa(int(autoscaler.Status.DesiredReplicas)))
+
+	if autoscaler.Spec.TargetCPUUtilizationPercentage != nil {
+		setMetadata(n, "autoscaler.targetCPUUtilization", strconv.Itoa(int(*autoscaler.Spec.TargetCPUUtilizationPercentage)))
+	}
+}
+
+func minReplicasOf(autoscaler autoscalingV1.HorizontalPodAutoscaler) int32 {
+	if autoscaler.Spec.MinReplicas != nil {
+		return *autoscaler.Spec.MinReplicas
+	}
+	return 1
+}
+
+// autoscalerForDeployment resolves the HPA scaling the given deployment,
+// matched via Spec.ScaleTargetRef.Name.
+func autoscalerForDeployment(autoscalers *autoscalingV1.HorizontalPodAutoscalerList, deploymentName string) *autoscalingV1.HorizontalPodAutoscaler {
+	for i, autoscaler := range autoscalers.Items {
+		if autoscaler.Spec.ScaleTargetRef.Name == deploymentName {
+			return &autoscalers.Items[i]
+		}
+	}
+
+	return nil
+}
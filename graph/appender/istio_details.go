@@ -3,82 +3,94 @@ package appender
 import (
 	"strings"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph/tree"
 	"github.com/kiali/kiali/kubernetes"
 )
 
-type IstioAppender struct{}
+const IstioAppenderName = "istio"
 
-func (a IstioAppender) AppendGraph(trees *[]tree.ServiceNode, namespaceName string) {
-	istioClient, err := kubernetes.NewClient()
-	checkError(err)
+func init() {
+	RegisterAppender(IstioAppender{})
+}
 
-	namespaceInfo := fetchNamespaceInfo(namespaceName, istioClient)
+// IstioAppender badges nodes with circuit-breaker and route-rule information
+// from RouteRules, VirtualServices, DestinationRules and DestinationPolicies.
+type IstioAppender struct{}
 
-	for _, tree := range *trees {
-		addRouteBadges(&tree, namespaceName, namespaceInfo)
-	}
+func (a IstioAppender) Name() string {
+	return IstioAppenderName
 }
 
-func fetchNamespaceInfo(namespaceName string, istioClient *kubernetes.IstioClient) *kubernetes.IstioDetails {
-	istioDetails, err := istioClient.GetIstioDetails(namespaceName, "")
-	checkError(err)
+func (a IstioAppender) DependsOn() []string {
+	return nil
+}
 
-	return istioDetails
+func (a IstioAppender) AppendGraph(trees *[]tree.ServiceNode, namespaceName string, context *AppenderContext) error {
+	for i := range *trees {
+		addRouteBadges(&(*trees)[i], namespaceName, context)
+	}
+	return nil
 }
 
-func addRouteBadges(n *tree.ServiceNode, namespaceName string, istioDetails *kubernetes.IstioDetails) {
-	applyCircuitBreakers(n, namespaceName, istioDetails)
-	applyRouteRules(n, namespaceName, istioDetails)
+func addRouteBadges(n *tree.ServiceNode, namespaceName string, context *AppenderContext) {
+	resolveBackingWorkload(n, context)
+
+	applyCircuitBreakers(n, namespaceName, context.IstioDetails)
+	applyTrafficPolicy(n, namespaceName, context.IstioDetails)
 
 	for _, child := range n.Children {
-		addRouteBadges(child, namespaceName, istioDetails)
+		addRouteBadges(child, namespaceName, context)
 	}
 }
 
-func applyCircuitBreakers(n *tree.ServiceNode, namespaceName string, istioDetails *kubernetes.IstioDetails) {
+// resolveBackingWorkload fills in n.Version from the service's backing workload
+// when the tree didn't already resolve one, and records which controller kind
+// (Deployment, StatefulSet, DaemonSet, ReplicaSet) owns the node so badges
+// attach regardless of controller kind. It reads from the namespace-wide
+// workload list the pipeline already fetched, rather than querying per node.
+func resolveBackingWorkload(n *tree.ServiceNode, context *AppenderContext) {
+	if context.Workloads == nil {
+		return
+	}
+
 	serviceName := strings.Split(n.Name, ".")[0]
-	version := n.Version
+	cfg := config.Get()
 
-	found := false
-	for _, destinationPolicy := range istioDetails.DestinationPolicies {
-		if kubernetes.CheckDestinationPolicyCircuitBreaker(destinationPolicy, namespaceName, serviceName, version) {
-			n.Metadata["hasCircuitBreaker"] = "true"
-			found = true
-			break
+	for _, workload := range context.Workloads.Items {
+		if workload.Labels[cfg.ServiceFilterLabelName] != serviceName {
+			continue
+		}
+		if n.Version != "" && workload.Labels[cfg.VersionFilterLabelName] != n.Version {
+			continue
 		}
-	}
 
-	// If we have found a CircuitBreaker from destinationPolicies we don't continue searching
-	if !found {
-		for _, destinationRule := range istioDetails.DestinationRules {
-			if kubernetes.CheckDestinationRuleCircuitBreaker(destinationRule, namespaceName, serviceName, version) {
-				n.Metadata["hasCircuitBreaker"] = "true"
-				break
-			}
+		if n.Version == "" {
+			n.Version = workload.Labels[cfg.VersionFilterLabelName]
 		}
+		setMetadata(n, "workloadKind", string(workload.Kind))
+		return
 	}
 }
 
-func applyRouteRules(n *tree.ServiceNode, namespaceName string, istioDetails *kubernetes.IstioDetails) {
+func applyCircuitBreakers(n *tree.ServiceNode, namespaceName string, istioDetails *kubernetes.IstioDetails) {
 	serviceName := strings.Split(n.Name, ".")[0]
 	version := n.Version
 
 	found := false
-	for _, routeRule := range istioDetails.RouteRules {
-		if kubernetes.CheckRouteRule(routeRule, namespaceName, serviceName, version) {
-			n.Metadata["hasRouteRule"] = "true"
+	for _, destinationPolicy := range istioDetails.DestinationPolicies {
+		if kubernetes.CheckDestinationPolicyCircuitBreaker(destinationPolicy, namespaceName, serviceName, version) {
+			setMetadata(n, "hasCircuitBreaker", "true")
 			found = true
 			break
 		}
 	}
 
-	// If we have found a RouteRule we don't continue searching
+	// If we have found a CircuitBreaker from destinationPolicies we don't continue searching
 	if !found {
-		subsets := kubernetes.GetDestinationRulesSubsets(istioDetails.DestinationRules, serviceName, version)
-		for _, virtualService := range istioDetails.VirtualServices {
-			if kubernetes.CheckVirtualService(virtualService, namespaceName, serviceName, subsets) {
-				n.Metadata["hasRouteRule"] = "true"
+		for _, destinationRule := range istioDetails.DestinationRules {
+			if kubernetes.CheckDestinationRuleCircuitBreaker(destinationRule, namespaceName, serviceName, version) {
+				setMetadata(n, "hasCircuitBreaker", "true")
 				break
 			}
 		}
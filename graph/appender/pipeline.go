@@ -0,0 +1,176 @@
+package appender
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph/tree"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// Pipeline runs a set of registered appenders against a namespace's service
+// trees, respecting each appender's DependsOn() ordering while running
+// independent appenders concurrently.
+type Pipeline struct {
+	levels [][]Appender
+}
+
+// NewPipeline resolves names against the registry and topologically sorts
+// them into dependency levels. An unknown name is a programmer/config error
+// and is skipped rather than failing the whole graph render.
+func NewPipeline(names []string) (*Pipeline, error) {
+	selected := make(map[string]Appender, len(names))
+	for _, name := range names {
+		a, ok := registry[name]
+		if !ok {
+			continue
+		}
+		selected[name] = a
+	}
+
+	levels, err := sortByDependencies(selected)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipeline{levels: levels}, nil
+}
+
+// sortByDependencies groups appenders into levels such that every appender in
+// level N only depends on appenders in levels < N, so each level can run fully
+// in parallel.
+func sortByDependencies(selected map[string]Appender) ([][]Appender, error) {
+	resolved := map[string]bool{}
+	levels := make([][]Appender, 0)
+
+	for len(resolved) < len(selected) {
+		level := make([]Appender, 0)
+
+		for name, a := range selected {
+			if resolved[name] {
+				continue
+			}
+
+			ready := true
+			for _, dep := range a.DependsOn() {
+				if _, inSelection := selected[dep]; !inSelection {
+					continue // depending on an appender that wasn't selected is not a cycle
+				}
+				if !resolved[dep] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				level = append(level, a)
+			}
+		}
+
+		if len(level) == 0 {
+			return nil, fmt.Errorf("appender pipeline: dependency cycle detected among %v", remainingNames(selected, resolved))
+		}
+
+		for _, a := range level {
+			resolved[a.Name()] = true
+		}
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+func remainingNames(selected map[string]Appender, resolved map[string]bool) []string {
+	names := make([]string, 0)
+	for name := range selected {
+		if !resolved[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Run fetches the shared AppenderContext once, then executes each dependency
+// level in turn, running every appender within a level concurrently.
+func (p *Pipeline) Run(trees *[]tree.ServiceNode, namespaceName string) error {
+	istioClient, err := kubernetes.NewClient()
+	if err != nil {
+		return err
+	}
+
+	context, err := fetchAppenderContext(namespaceName, istioClient)
+	if err != nil {
+		return err
+	}
+
+	for _, level := range p.levels {
+		if err := runLevel(level, trees, namespaceName, context); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runLevel(level []Appender, trees *[]tree.ServiceNode, namespaceName string, context *AppenderContext) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(level))
+
+	for _, a := range level {
+		wg.Add(1)
+		go func(a Appender) {
+			defer wg.Done()
+			errs <- a.AppendGraph(trees, namespaceName, context)
+		}(a)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fetchAppenderContext(namespaceName string, istioClient *kubernetes.IstioClient) (*AppenderContext, error) {
+	istioDetails, err := istioClient.GetIstioDetails(namespaceName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := istioClient.GetWorkloads(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	autoscalers, err := istioClient.GetAutoscalers(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppenderContext{
+		IstioDetails: istioDetails,
+		Workloads:    workloads,
+		Autoscalers:  autoscalers,
+	}, nil
+}
+
+// ParseAppenderNames splits the "?appenders=istio,health,hpa" query param into
+// appender names, falling back to config.yaml's appender list and finally to
+// every registered appender when neither is set.
+func ParseAppenderNames(queryParam string) []string {
+	if queryParam != "" {
+		return strings.Split(queryParam, ",")
+	}
+
+	if cfgNames := config.Get().Appenders; len(cfgNames) > 0 {
+		return cfgNames
+	}
+
+	return DefaultAppenderNames()
+}
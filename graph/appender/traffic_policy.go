@@ -0,0 +1,122 @@
+package appender
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/graph/tree"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// applyTrafficPolicy replaces the old flat `hasRouteRule=true` boolean with
+// the actual weights/match/fault/retry/mirror policy governing traffic out of
+// n. Weights and mirroring are attached as edge metadata keyed by destination
+// child; match, fault, retry and timeout are attached to n as a whole.
+func applyTrafficPolicy(n *tree.ServiceNode, namespaceName string, istioDetails *kubernetes.IstioDetails) {
+	serviceName := strings.Split(n.Name, ".")[0]
+	version := n.Version
+
+	var policy *kubernetes.TrafficPolicy
+	for _, routeRule := range istioDetails.RouteRules {
+		if policy = kubernetes.ExtractRouteRule(routeRule, namespaceName, serviceName, version); policy != nil {
+			break
+		}
+	}
+
+	// If we have found a policy from RouteRules we don't continue searching
+	if policy == nil {
+		subsets := kubernetes.GetDestinationRulesSubsets(istioDetails.DestinationRules, serviceName, version)
+		for _, virtualService := range istioDetails.VirtualServices {
+			if policy = kubernetes.ExtractVirtualService(virtualService, namespaceName, serviceName, subsets); policy != nil {
+				break
+			}
+		}
+	}
+
+	if policy == nil {
+		return
+	}
+
+	attachEdgeMetadata(n, policy)
+}
+
+// attachEdgeMetadata records the weight on the outgoing edge to each weighted
+// destination and marks the edge to a mirrored one. Match, fault, retry and
+// timeout are recorded once as flat keys on n, not per edge. It appends a
+// synthetic child for any
+// destination (including a mirrored one) that isn't already one of n's
+// children, so every weighted or mirrored destination shows up as its own
+// edge even before it has carried any traffic.
+func attachEdgeMetadata(n *tree.ServiceNode, policy *kubernetes.TrafficPolicy) {
+	for version, weight := range policy.Weights {
+		child := findOrAddChild(n, n.Name, version)
+		setMetadata(n, edgeKey(child, "weight"), strconv.Itoa(weight))
+	}
+
+	for _, match := range policy.Match {
+		if match.URI != "" {
+			setMetadata(n, "routeMatch.uri", match.URI)
+		}
+		if match.Method != "" {
+			setMetadata(n, "routeMatch.method", match.Method)
+		}
+	}
+
+	if policy.Fault != nil {
+		if policy.Fault.AbortHTTPStatus != 0 {
+			setMetadata(n, "fault.abortHttpStatus", strconv.Itoa(policy.Fault.AbortHTTPStatus))
+			setMetadata(n, "fault.abortPercent", strconv.FormatFloat(policy.Fault.AbortPercent, 'f', -1, 64))
+		}
+		if policy.Fault.DelaySeconds != 0 {
+			setMetadata(n, "fault.delaySeconds", strconv.FormatFloat(policy.Fault.DelaySeconds, 'f', -1, 64))
+			setMetadata(n, "fault.delayPercent", strconv.FormatFloat(policy.Fault.DelayPercent, 'f', -1, 64))
+		}
+	}
+
+	if policy.Retry != nil {
+		setMetadata(n, "retry.attempts", strconv.Itoa(policy.Retry.Attempts))
+	}
+
+	if policy.Timeout != 0 {
+		setMetadata(n, "timeout", policy.Timeout.String())
+	}
+
+	if policy.Mirror != nil {
+		name := policy.Mirror.Host
+		if name == "" {
+			name = n.Name
+		}
+		mirrorChild := findOrAddChild(n, name, policy.Mirror.Subset)
+		setMetadata(mirrorChild, "isMirror", "true")
+		setMetadata(n, edgeKey(mirrorChild, "mirror"), "true")
+	}
+}
+
+func edgeKey(child *tree.ServiceNode, attribute string) string {
+	return fmt.Sprintf("edge.%s.%s.%s", child.Name, child.Version, attribute)
+}
+
+// findOrAddChild returns n's existing child matching (name, version),
+// synthesizing one if the tree hasn't discovered it yet (e.g. a
+// traffic-shifted or mirrored destination with no traffic served so far), so
+// it still gets its own edge. It shares metadataMu with setMetadata since it
+// mutates n.Children, the same shared tree same-level appenders run over.
+func findOrAddChild(n *tree.ServiceNode, name, version string) *tree.ServiceNode {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+
+	for _, child := range n.Children {
+		if child.Name == name && child.Version == version {
+			return child
+		}
+	}
+
+	child := &tree.ServiceNode{
+		Name:     name,
+		Version:  version,
+		Metadata: map[string]string{},
+	}
+	n.Children = append(n.Children, child)
+	return child
+}
@@ -0,0 +1,95 @@
+package appender
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph/tree"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+const WorkloadStatusAppenderName = "health"
+
+func init() {
+	RegisterAppender(WorkloadStatusAppender{})
+}
+
+// WorkloadStatusAppender badges graph nodes with the rollout health of their
+// backing workload, alongside the existing circuit-breaker and route-rule badges.
+type WorkloadStatusAppender struct{}
+
+func (a WorkloadStatusAppender) Name() string {
+	return WorkloadStatusAppenderName
+}
+
+// DependsOn the istio appender so n.Version and the backing workload kind are
+// already resolved by the time this one runs.
+func (a WorkloadStatusAppender) DependsOn() []string {
+	return []string{IstioAppenderName}
+}
+
+func (a WorkloadStatusAppender) AppendGraph(trees *[]tree.ServiceNode, namespaceName string, context *AppenderContext) error {
+	istioClient, err := kubernetes.NewClient()
+	if err != nil {
+		return err
+	}
+
+	for i := range *trees {
+		addWorkloadStatusBadges(&(*trees)[i], namespaceName, context, istioClient)
+	}
+	return nil
+}
+
+func addWorkloadStatusBadges(n *tree.ServiceNode, namespaceName string, context *AppenderContext, istioClient *kubernetes.IstioClient) {
+	applyWorkloadStatus(n, namespaceName, context, istioClient)
+
+	for _, child := range n.Children {
+		addWorkloadStatusBadges(child, namespaceName, context, istioClient)
+	}
+}
+
+func applyWorkloadStatus(n *tree.ServiceNode, namespaceName string, context *AppenderContext, istioClient *kubernetes.IstioClient) {
+	workload := workloadForNode(n, context)
+	if workload == nil {
+		return
+	}
+
+	status := workload.Status
+
+	setMetadata(n, "readyReplicas", strconv.Itoa(int(status.ReadyReplicas)))
+	setMetadata(n, "desiredReplicas", strconv.Itoa(int(status.DesiredReplicas)))
+	setMetadata(n, "rolloutStatus", string(status.RolloutStatus))
+
+	// The namespace-wide context doesn't carry per-workload pod lists, so this is
+	// the one call in the pipeline that still goes per node.
+	serviceName := strings.Split(n.Name, ".")[0]
+	pods, err := istioClient.GetServicePods(namespaceName, serviceName, n.Version)
+	if err == nil && pods != nil && kubernetes.HasUnhealthyPods(pods.Items) {
+		setMetadata(n, "hasUnhealthyPods", "true")
+	}
+}
+
+// workloadForNode resolves n's backing Workload from the namespace-wide list
+// the pipeline already fetched, matching on the same canonical labels used to
+// build the tree (service name, and version when the node has one).
+func workloadForNode(n *tree.ServiceNode, context *AppenderContext) *kubernetes.Workload {
+	if context.Workloads == nil {
+		return nil
+	}
+
+	serviceName := strings.Split(n.Name, ".")[0]
+	cfg := config.Get()
+
+	for i, workload := range context.Workloads.Items {
+		if workload.Labels[cfg.ServiceFilterLabelName] != serviceName {
+			continue
+		}
+		if n.Version != "" && workload.Labels[cfg.VersionFilterLabelName] != n.Version {
+			continue
+		}
+		return &context.Workloads.Items[i]
+	}
+
+	return nil
+}
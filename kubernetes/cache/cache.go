@@ -0,0 +1,241 @@
+// Package cache provides an informer-backed, in-memory view of the cluster so
+// that repeated graph refreshes don't turn into dozens of List round-trips to
+// the API server. It is deliberately read-only: callers fall back to the live
+// API whenever the cache hasn't synced yet or doesn't hold a given namespace.
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/api/apps/v1beta1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sruntimecache "k8s.io/client-go/tools/cache"
+
+	"k8s.io/client-go/informers"
+	kube "k8s.io/client-go/kubernetes"
+	appsv1beta1listers "k8s.io/client-go/listers/apps/v1beta1"
+	autoscalingv1listers "k8s.io/client-go/listers/autoscaling/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// namespaceLabelIndex is a SharedIndexInformer index keyed on
+// "<namespace>/<label>=<value>", registered on the pod informer. GetPods does
+// not query it: it lists the namespace through the pod lister and filters by
+// the selector.
+const namespaceLabelIndex = "namespaceLabel"
+
+// KialiCache is the shared, informer-backed read cache for the Kubernetes
+// objects kiali's graph appenders need on every render.
+type KialiCache struct {
+	factory informers.SharedInformerFactory
+
+	serviceLister    corev1listers.ServiceLister
+	podLister        corev1listers.PodLister
+	endpointsLister  corev1listers.EndpointsLister
+	deploymentLister appsv1beta1listers.DeploymentLister
+	hpaLister        autoscalingv1listers.HorizontalPodAutoscalerLister
+
+	podInformer k8sruntimecache.SharedIndexInformer
+
+	stopChan chan struct{}
+	hits     uint64
+	misses   uint64
+}
+
+var (
+	instance *KialiCache
+	initMu   sync.Mutex
+)
+
+// GetKialiCache returns the process-wide cache instance, or nil if
+// NewKialiCache hasn't been called yet (e.g. caching is disabled in config).
+// Callers must treat a nil cache as "always fall back to the live API".
+func GetKialiCache() *KialiCache {
+	return instance
+}
+
+// NewKialiCache builds and starts the shared informer factory, blocking until
+// the initial list+watch sync for every informer completes. resync is the
+// full relist period (the config.yaml "cache duration" knob). It's safe to
+// call repeatedly: once an instance exists it's returned as-is, but a failed
+// attempt (e.g. the API server wasn't reachable yet) leaves initialization
+// retriable on the next call instead of wedging caching off forever.
+func NewKialiCache(client kube.Interface, resync time.Duration) (*KialiCache, error) {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if instance != nil {
+		return instance, nil
+	}
+
+	factory := informers.NewSharedInformerFactory(client, resync)
+
+	kc := &KialiCache{
+		factory:          factory,
+		serviceLister:    factory.Core().V1().Services().Lister(),
+		podLister:        factory.Core().V1().Pods().Lister(),
+		endpointsLister:  factory.Core().V1().Endpoints().Lister(),
+		deploymentLister: factory.Apps().V1beta1().Deployments().Lister(),
+		hpaLister:        factory.Autoscaling().V1().HorizontalPodAutoscalers().Lister(),
+		stopChan:         make(chan struct{}),
+	}
+
+	podInformer := factory.Core().V1().Pods().Informer()
+	if err := podInformer.AddIndexers(k8sruntimecache.Indexers{namespaceLabelIndex: namespaceLabelIndexFunc}); err != nil {
+		return nil, err
+	}
+	kc.podInformer = podInformer
+
+	factory.Start(kc.stopChan)
+	for resourceType, synced := range factory.WaitForCacheSync(kc.stopChan) {
+		if !synced {
+			// Stop the watches factory.Start already launched; otherwise a
+			// retried call after a transient failure leaks a whole new set of
+			// informers against the API server on top of the dead ones.
+			kc.Stop()
+			return nil, fmt.Errorf("kiali cache: informer for %v failed to sync", resourceType)
+		}
+	}
+
+	instance = kc
+	return instance, nil
+}
+
+// Stop tears down the informer factory's watches. Intended for tests; the
+// server keeps a single cache alive for its whole lifetime.
+func (c *KialiCache) Stop() {
+	if c == nil {
+		return
+	}
+	close(c.stopChan)
+}
+
+func namespaceLabelIndexFunc(obj interface{}) ([]string, error) {
+	accessor, ok := obj.(meta_v1.Object)
+	if !ok {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(accessor.GetLabels()))
+	for k, v := range accessor.GetLabels() {
+		keys = append(keys, accessor.GetNamespace()+"/"+k+"="+v)
+	}
+	return keys, nil
+}
+
+// GetServices lists every Service in namespace from the cache.
+func (c *KialiCache) GetServices(namespace string) ([]v1.Service, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali cache is not initialized")
+	}
+
+	services, err := c.serviceLister.Services(namespace).List(labels.Everything())
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, err
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	result := make([]v1.Service, len(services))
+	for i, s := range services {
+		result[i] = *s
+	}
+	return result, nil
+}
+
+// GetPods lists the Pods in namespace matching selector from the cache.
+func (c *KialiCache) GetPods(namespace string, selector labels.Selector) ([]v1.Pod, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali cache is not initialized")
+	}
+
+	pods, err := c.podLister.Pods(namespace).List(selector)
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, err
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	result := make([]v1.Pod, len(pods))
+	for i, p := range pods {
+		result[i] = *p
+	}
+	return result, nil
+}
+
+// GetDeployments lists every Deployment in namespace from the cache.
+func (c *KialiCache) GetDeployments(namespace string) ([]v1beta1.Deployment, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali cache is not initialized")
+	}
+
+	deployments, err := c.deploymentLister.Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, err
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	result := make([]v1beta1.Deployment, len(deployments))
+	for i, d := range deployments {
+		result[i] = *d
+	}
+	return result, nil
+}
+
+// GetEndpoints fetches the Endpoints object backing a single Service from the cache.
+func (c *KialiCache) GetEndpoints(namespace, name string) (*v1.Endpoints, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali cache is not initialized")
+	}
+
+	endpoints, err := c.endpointsLister.Endpoints(namespace).Get(name)
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, err
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	return endpoints, nil
+}
+
+// GetAutoscalers lists every HorizontalPodAutoscaler in namespace from the cache.
+func (c *KialiCache) GetAutoscalers(namespace string) ([]autoscalingV1.HorizontalPodAutoscaler, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali cache is not initialized")
+	}
+
+	hpas, err := c.hpaLister.HorizontalPodAutoscalers(namespace).List(labels.Everything())
+	if err != nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, err
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	result := make([]autoscalingV1.HorizontalPodAutoscaler, len(hpas))
+	for i, h := range hpas {
+		result[i] = *h
+	}
+	return result, nil
+}
+
+// HitRate returns the fraction of cache reads (GetServices, GetPods, ...)
+// that were served without falling back to the live API, for metrics export.
+func (c *KialiCache) HitRate() float64 {
+	if c == nil {
+		return 0
+	}
+
+	hits := atomic.LoadUint64(&c.hits)
+	misses := atomic.LoadUint64(&c.misses)
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
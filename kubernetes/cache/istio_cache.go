@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	k8sruntimecache "k8s.io/client-go/tools/cache"
+)
+
+// istioCRDResources are the Istio CRDs kiali reads on every graph render.
+// They have no generated listers, so we watch them directly through the
+// dynamic client instead of client-go's typed SharedInformerFactory.
+var istioCRDResources = []schema.GroupVersionResource{
+	{Group: "config.istio.io", Version: "v1alpha2", Resource: "routerules"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "virtualservices"},
+	{Group: "networking.istio.io", Version: "v1alpha3", Resource: "destinationrules"},
+	{Group: "config.istio.io", Version: "v1alpha2", Resource: "destinationpolicies"},
+}
+
+// IstioCache is a watch-based cache over the Istio config CRDs, keyed by
+// GroupVersionResource since they share no common typed client.
+//
+// Unlike KialiCache, nothing reads from IstioCache yet: IstioClient.
+// GetIstioDetails (the one caller that would benefit) lives outside this
+// module slice, so wiring its RouteRule/VirtualService/DestinationRule/
+// DestinationPolicy fetch through List with a live fallback has to happen
+// there. Until that caller exists, NewIstioCache only registers the
+// informers; call Start once there's a real consumer, so this doesn't spend
+// API server watches on a cache nobody reads.
+type IstioCache struct {
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	informers map[schema.GroupVersionResource]k8sruntimecache.SharedIndexInformer
+	stopChan  chan struct{}
+}
+
+var (
+	istioInstance *IstioCache
+	istioInitMu   sync.Mutex
+)
+
+// GetIstioCache returns the process-wide Istio CRD cache, or nil if
+// NewIstioCache hasn't been called yet.
+func GetIstioCache() *IstioCache {
+	return istioInstance
+}
+
+// NewIstioCache registers a dynamic informer for every Istio CRD kiali
+// understands, but does not start watching them yet; call Start once a real
+// consumer is wired to read from the cache. It's safe to call repeatedly:
+// once an instance exists it's returned as-is.
+func NewIstioCache(client dynamic.Interface, resync time.Duration) (*IstioCache, error) {
+	istioInitMu.Lock()
+	defer istioInitMu.Unlock()
+
+	if istioInstance != nil {
+		return istioInstance, nil
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, resync)
+
+	ic := &IstioCache{
+		factory:   factory,
+		informers: make(map[schema.GroupVersionResource]k8sruntimecache.SharedIndexInformer, len(istioCRDResources)),
+		stopChan:  make(chan struct{}),
+	}
+
+	for _, gvr := range istioCRDResources {
+		ic.informers[gvr] = factory.ForResource(gvr).Informer()
+	}
+
+	istioInstance = ic
+	return istioInstance, nil
+}
+
+// Start begins watching every registered Istio CRD and blocks until each has
+// completed its initial list+watch sync. A failed sync stops the watches it
+// started and discards the registered instance, so the next NewIstioCache
+// call rebuilds a fresh factory instead of retrying Start on one whose
+// informers client-go won't restart.
+func (c *IstioCache) Start() error {
+	if c == nil {
+		return fmt.Errorf("kiali istio cache is not initialized")
+	}
+
+	c.factory.Start(c.stopChan)
+	for gvr, synced := range c.factory.WaitForCacheSync(c.stopChan) {
+		if !synced {
+			c.Stop()
+
+			istioInitMu.Lock()
+			if istioInstance == c {
+				istioInstance = nil
+			}
+			istioInitMu.Unlock()
+
+			return fmt.Errorf("kiali cache: istio informer for %v failed to sync", gvr)
+		}
+	}
+
+	return nil
+}
+
+// Stop tears down every Istio CRD watch.
+func (c *IstioCache) Stop() {
+	if c == nil {
+		return
+	}
+	close(c.stopChan)
+}
+
+// List returns every object of the given GroupVersionResource in namespace.
+func (c *IstioCache) List(gvr schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+	if c == nil {
+		return nil, fmt.Errorf("kiali istio cache is not initialized")
+	}
+
+	informer, ok := c.informers[gvr]
+	if !ok {
+		return nil, fmt.Errorf("kiali istio cache: no informer registered for %v", gvr)
+	}
+
+	items := make([]unstructured.Unstructured, 0)
+	for _, obj := range informer.GetIndexer().List() {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok || u.GetNamespace() != namespace {
+			continue
+		}
+		items = append(items, *u)
+	}
+
+	return items, nil
+}
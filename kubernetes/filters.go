@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// FilterWorkloadsForService narrows a namespace-wide WorkloadList down to the
+// workloads that actually back the given service, determined by whether the
+// workload's selector matches at least one of the service's own pods. This
+// generalizes the old Deployment-only FilterDeploymentsForService to every
+// controller kind GetWorkloads knows about (Deployment, StatefulSet,
+// DaemonSet, ReplicaSet).
+func FilterWorkloadsForService(service *v1.Service, pods *v1.PodList, workloads *WorkloadList) []Workload {
+	podLabelSets := make([]labels.Set, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		podLabelSets = append(podLabelSets, labels.Set(pod.Labels))
+	}
+
+	filtered := make([]Workload, 0, len(workloads.Items))
+	for _, workload := range workloads.Items {
+		for _, podLabels := range podLabelSets {
+			if matchesSelector(workload.Selector, podLabels) {
+				filtered = append(filtered, workload)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/cache"
 	"k8s.io/api/apps/v1beta1"
 	autoscalingV1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/api/core/v1"
@@ -60,27 +61,39 @@ func (in *IstioClient) GetNamespaces() (*v1.NamespaceList, error) {
 // It returns an error on any problem.
 func (in *IstioClient) GetServices(namespace string) (*ServiceList, error) {
 	var err error
-	servicesChan, podsChan, deploymentsChan := make(chan servicesResponse), make(chan podsResponse), make(chan deploymentsResponse)
+	servicesChan, podsChan, deploymentsChan, autoscalersChan, workloadsChan :=
+		make(chan servicesResponse), make(chan podsResponse), make(chan deploymentsResponse),
+		make(chan autoscalersResponse), make(chan workloadsResponse)
 
 	go in.getServiceList(namespace, servicesChan)
 	go in.getPodsList(namespace, podsChan)
 	go in.getDeployments(namespace, deploymentsChan)
+	go in.getAutoscalers(namespace, autoscalersChan)
+	go in.getWorkloads(namespace, nil, workloadsChan)
 
 	servicesResponse := <-servicesChan
 	podsResponse := <-podsChan
 	deploymentsResponse := <-deploymentsChan
+	autoscalersResponse := <-autoscalersChan
+	workloadsResponse := <-workloadsChan
 
 	services := &ServiceList{}
 	services.Services = servicesResponse.services
 	services.Pods = podsResponse.pods
 	services.Deployments = deploymentsResponse.deployments
+	services.Autoscalers = autoscalersResponse.autoscalers
+	services.Workloads = workloadsResponse.workloads
 
 	if servicesResponse.err != nil {
 		err = servicesResponse.err
 	} else if podsResponse.err != nil {
 		err = podsResponse.err
-	} else {
+	} else if deploymentsResponse.err != nil {
 		err = deploymentsResponse.err
+	} else if autoscalersResponse.err != nil {
+		err = autoscalersResponse.err
+	} else {
+		err = workloadsResponse.err
 	}
 
 	return services, err
@@ -92,6 +105,17 @@ func (in *IstioClient) GetDeployments(namespace string) (*v1beta1.DeploymentList
 	return in.k8s.AppsV1beta1().Deployments(namespace).List(emptyListOptions)
 }
 
+// GetAutoscalers returns the HorizontalPodAutoscalers for a given namespace,
+// reading from the shared informer cache when it's available. Callers that
+// only need autoscalers should use this instead of GetServices, which fans
+// out Services/Pods/Deployments/Workloads lists just to reach .Autoscalers.
+func (in *IstioClient) GetAutoscalers(namespace string) (*autoscalingV1.HorizontalPodAutoscalerList, error) {
+	autoscalersChan := make(chan autoscalersResponse)
+	go in.getAutoscalers(namespace, autoscalersChan)
+	response := <-autoscalersChan
+	return response.autoscalers, response.err
+}
+
 // GetService returns the definition of a specific service.
 // It returns an error on any problem.
 func (in *IstioClient) GetService(namespace, serviceName string) (*v1.Service, error) {
@@ -112,6 +136,7 @@ func (in *IstioClient) GetServiceDetails(namespace string, serviceName string) (
 	endpointsChan := make(chan endpointsResponse)
 	autoscalersChan := make(chan autoscalersResponse)
 	podsChan := make(chan podsResponse)
+	workloadsChan := make(chan workloadsResponse)
 
 	// Fetch the service first to ensure it exists, then fetch details in parallel
 	service, err := in.GetService(namespace, serviceName)
@@ -120,6 +145,13 @@ func (in *IstioClient) GetServiceDetails(namespace string, serviceName string) (
 	}
 
 	go func() {
+		if kialiCache := cache.GetKialiCache(); kialiCache != nil {
+			if endpoints, err := kialiCache.GetEndpoints(namespace, serviceName); err == nil {
+				endpointsChan <- endpointsResponse{endpoints: endpoints}
+				return
+			}
+		}
+
 		endpoints, err := in.k8s.CoreV1().Endpoints(namespace).Get(serviceName, emptyGetOptions)
 		endpointsChan <- endpointsResponse{endpoints: endpoints, err: err}
 	}()
@@ -134,12 +166,16 @@ func (in *IstioClient) GetServiceDetails(namespace string, serviceName string) (
 		podsChan <- podsResponse{pods: pods, err: err}
 	}()
 
-	// Last fetch can be performed in main thread. This list is potentially too large and will be narrowed down below
+	// This list is potentially too large and will be narrowed down below. Fetched
+	// in the main thread, ahead of getWorkloads, so getWorkloads can reuse it
+	// instead of listing Deployments a second time.
 	deployments, err := in.k8s.AppsV1beta1().Deployments(namespace).List(emptyListOptions)
 	if err != nil {
 		return nil, err
 	}
 
+	go in.getWorkloads(namespace, deployments, workloadsChan)
+
 	serviceDetails := ServiceDetails{}
 
 	serviceDetails.Service = service
@@ -166,6 +202,17 @@ func (in *IstioClient) GetServiceDetails(namespace string, serviceName string) (
 	serviceDetails.Deployments = &v1beta1.DeploymentList{
 		Items: FilterDeploymentsForService(service, podsResponse.pods, deployments)}
 
+	// Narrow down the autoscalers list to only the ones scaling the service's own deployments
+	serviceDetails.Autoscalers = filterAutoscalersByDeployments(
+		getDeploymentNames(serviceDetails.Deployments), autoscalersResponse.autoscalers)
+
+	workloadsResponse := <-workloadsChan
+	if workloadsResponse.err != nil {
+		return nil, workloadsResponse.err
+	}
+	serviceDetails.Workloads = &WorkloadList{
+		Items: FilterWorkloadsForService(service, podsResponse.pods, workloadsResponse.workloads)}
+
 	return &serviceDetails, nil
 }
 
@@ -197,7 +244,7 @@ func filterAutoscalersByDeployments(deploymentNames []string, al *autoscalingV1.
 }
 
 func getDeploymentNames(deployments *v1beta1.DeploymentList) []string {
-	deploymentNames := make([]string, len(deployments.Items))
+	deploymentNames := make([]string, 0, len(deployments.Items))
 	for _, deployment := range deployments.Items {
 		deploymentNames = append(deploymentNames, deployment.Name)
 	}
@@ -205,17 +252,52 @@ func getDeploymentNames(deployments *v1beta1.DeploymentList) []string {
 	return deploymentNames
 }
 
+// getServiceList reads from the shared informer cache when it's available and
+// synced, falling back to a live List call on a cache miss.
 func (in *IstioClient) getServiceList(namespace string, servicesChan chan servicesResponse) {
+	if kialiCache := cache.GetKialiCache(); kialiCache != nil {
+		if items, err := kialiCache.GetServices(namespace); err == nil {
+			servicesChan <- servicesResponse{services: &v1.ServiceList{Items: items}}
+			return
+		}
+	}
+
 	services, err := in.k8s.CoreV1().Services(namespace).List(emptyListOptions)
 	servicesChan <- servicesResponse{services: services, err: err}
 }
 
 func (in *IstioClient) getPodsList(namespace string, podsChan chan podsResponse) {
+	if kialiCache := cache.GetKialiCache(); kialiCache != nil {
+		if items, err := kialiCache.GetPods(namespace, labels.Everything()); err == nil {
+			podsChan <- podsResponse{pods: &v1.PodList{Items: items}}
+			return
+		}
+	}
+
 	pods, err := in.k8s.CoreV1().Pods(namespace).List(emptyListOptions)
 	podsChan <- podsResponse{pods: pods, err: err}
 }
 
 func (in *IstioClient) getDeployments(namespace string, deploymentsChan chan deploymentsResponse) {
+	if kialiCache := cache.GetKialiCache(); kialiCache != nil {
+		if items, err := kialiCache.GetDeployments(namespace); err == nil {
+			deploymentsChan <- deploymentsResponse{deployments: &v1beta1.DeploymentList{Items: items}}
+			return
+		}
+	}
+
 	deployments, err := in.k8s.AppsV1beta1().Deployments(namespace).List(emptyListOptions)
 	deploymentsChan <- deploymentsResponse{deployments: deployments, err: err}
 }
+
+func (in *IstioClient) getAutoscalers(namespace string, autoscalersChan chan autoscalersResponse) {
+	if kialiCache := cache.GetKialiCache(); kialiCache != nil {
+		if items, err := kialiCache.GetAutoscalers(namespace); err == nil {
+			autoscalersChan <- autoscalersResponse{autoscalers: &autoscalingV1.HorizontalPodAutoscalerList{Items: items}}
+			return
+		}
+	}
+
+	autoscalers, err := in.k8s.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(emptyListOptions)
+	autoscalersChan <- autoscalersResponse{autoscalers: autoscalers, err: err}
+}
@@ -0,0 +1,256 @@
+package kubernetes
+
+import "time"
+
+// HTTPMatch is a single HTTP match condition lifted from a RouteRule's
+// `match.http` block or a VirtualService HTTPRoute's `match` list.
+type HTTPMatch struct {
+	Headers map[string]string
+	URI     string
+	Method  string
+}
+
+// FaultInjection is the subset of RouteRule/VirtualService fault injection
+// kiali cares about for badging: an artificial delay and/or abort.
+type FaultInjection struct {
+	DelaySeconds    float64
+	DelayPercent    float64
+	AbortHTTPStatus int
+	AbortPercent    float64
+}
+
+// RetryPolicy is a route's retry budget.
+type RetryPolicy struct {
+	Attempts      int
+	PerTryTimeout time.Duration
+}
+
+// Destination names a subset/version of a service a route can send to.
+type Destination struct {
+	Host   string
+	Subset string
+}
+
+// TrafficPolicy is the structured form of what a RouteRule or VirtualService
+// actually does to traffic for one service, replacing the old flat
+// `hasRouteRule=true` boolean with enough detail to badge edges individually.
+type TrafficPolicy struct {
+	// Weights maps destination subset/version to the percentage of traffic routed to it.
+	Weights map[string]int
+	Match   []HTTPMatch
+	Fault   *FaultInjection
+	Retry   *RetryPolicy
+	Timeout time.Duration
+	Mirror  *Destination
+}
+
+// ExtractRouteRule returns the structured TrafficPolicy for routeRule if it
+// applies to (namespace, serviceName, version), or nil otherwise. It
+// generalizes CheckRouteRule, which only reported whether a rule matched.
+func ExtractRouteRule(routeRule IstioObject, namespace, serviceName, version string) *TrafficPolicy {
+	if !CheckRouteRule(routeRule, namespace, serviceName, version) {
+		return nil
+	}
+
+	spec := routeRule.GetSpec()
+	policy := &TrafficPolicy{Weights: weightsFromRoute(asSlice(spec["route"]))}
+
+	if httpFault := asMap(spec["httpFault"]); httpFault != nil {
+		policy.Fault = faultFromRouteRule(httpFault)
+	}
+
+	if httpReqTimeout := asMap(spec["httpReqTimeout"]); httpReqTimeout != nil {
+		if simple := asMap(httpReqTimeout["simpleTimeout"]); simple != nil {
+			policy.Timeout = durationFromString(asString(simple["timeout"]))
+		}
+	}
+
+	if retry := asMap(spec["httpReqRetries"]); retry != nil {
+		policy.Retry = &RetryPolicy{Attempts: int(asFloat(retry["simpleRetry"]))}
+	}
+
+	return policy
+}
+
+// ExtractVirtualService returns the structured TrafficPolicy for
+// virtualService if it applies to (namespace, serviceName) for any of
+// subsets, or nil otherwise. It generalizes CheckVirtualService.
+func ExtractVirtualService(virtualService IstioObject, namespace, serviceName string, subsets []string) *TrafficPolicy {
+	if !CheckVirtualService(virtualService, namespace, serviceName, subsets) {
+		return nil
+	}
+
+	spec := virtualService.GetSpec()
+	policy := &TrafficPolicy{Weights: map[string]int{}}
+
+	for _, httpRoute := range asSlice(spec["http"]) {
+		route := asMap(httpRoute)
+		if route == nil {
+			continue
+		}
+
+		for subset, weight := range weightsFromRoute(asSlice(route["route"])) {
+			policy.Weights[subset] += weight
+		}
+
+		for _, match := range asSlice(route["match"]) {
+			policy.Match = append(policy.Match, httpMatchFromVirtualService(asMap(match)))
+		}
+
+		if fault := asMap(route["fault"]); fault != nil && policy.Fault == nil {
+			policy.Fault = faultFromVirtualService(fault)
+		}
+
+		if retries := asMap(route["retries"]); retries != nil && policy.Retry == nil {
+			policy.Retry = &RetryPolicy{
+				Attempts:      int(asFloat(retries["attempts"])),
+				PerTryTimeout: durationFromString(asString(retries["perTryTimeout"])),
+			}
+		}
+
+		if policy.Timeout == 0 {
+			policy.Timeout = durationFromString(asString(route["timeout"]))
+		}
+
+		if mirror := asMap(route["mirror"]); mirror != nil && policy.Mirror == nil {
+			policy.Mirror = &Destination{Host: asString(mirror["host"]), Subset: asString(mirror["subset"])}
+		}
+	}
+
+	return policy
+}
+
+func weightsFromRoute(destinationWeights []interface{}) map[string]int {
+	weights := make(map[string]int, len(destinationWeights))
+
+	for _, dw := range destinationWeights {
+		entry := asMap(dw)
+		if entry == nil {
+			continue
+		}
+
+		subset := subsetFromDestinationWeight(entry)
+		weight := int(asFloat(entry["weight"]))
+		if weight == 0 && len(destinationWeights) == 1 {
+			// A single, unweighted destination gets all the traffic.
+			weight = 100
+		}
+		weights[subset] += weight
+	}
+
+	return weights
+}
+
+func subsetFromDestinationWeight(entry map[string]interface{}) string {
+	// RouteRule (v1alpha1) style: destination.labels.version
+	if destination := asMap(entry["destination"]); destination != nil {
+		if labels := asMap(destination["labels"]); labels != nil {
+			if version := asString(labels["version"]); version != "" {
+				return version
+			}
+		}
+		// VirtualService (v1alpha3) style: destination.subset
+		if subset := asString(destination["subset"]); subset != "" {
+			return subset
+		}
+	}
+
+	if labels := asMap(entry["labels"]); labels != nil {
+		if version := asString(labels["version"]); version != "" {
+			return version
+		}
+	}
+
+	return "unknown"
+}
+
+func faultFromRouteRule(httpFault map[string]interface{}) *FaultInjection {
+	fault := &FaultInjection{}
+
+	if delay := asMap(httpFault["delay"]); delay != nil {
+		fault.DelaySeconds = durationFromString(asString(delay["fixedDelay"])).Seconds()
+		fault.DelayPercent = asFloat(delay["percent"])
+	}
+	if abort := asMap(httpFault["abort"]); abort != nil {
+		fault.AbortHTTPStatus = int(asFloat(abort["httpStatus"]))
+		fault.AbortPercent = asFloat(abort["percent"])
+	}
+
+	return fault
+}
+
+func faultFromVirtualService(fault map[string]interface{}) *FaultInjection {
+	injection := &FaultInjection{}
+
+	if delay := asMap(fault["delay"]); delay != nil {
+		injection.DelaySeconds = durationFromString(asString(delay["fixedDelay"])).Seconds()
+		injection.DelayPercent = asFloat(delay["percent"])
+	}
+	if abort := asMap(fault["abort"]); abort != nil {
+		injection.AbortHTTPStatus = int(asFloat(abort["httpStatus"]))
+		injection.AbortPercent = asFloat(abort["percent"])
+	}
+
+	return injection
+}
+
+func httpMatchFromVirtualService(match map[string]interface{}) HTTPMatch {
+	if match == nil {
+		return HTTPMatch{}
+	}
+
+	httpMatch := HTTPMatch{Headers: map[string]string{}}
+
+	if uri := asMap(match["uri"]); uri != nil {
+		httpMatch.URI = asString(uri["exact"])
+	}
+	if method := asMap(match["method"]); method != nil {
+		httpMatch.Method = asString(method["exact"])
+	}
+	for header, matcher := range asMap(match["headers"]) {
+		if m := asMap(matcher); m != nil {
+			httpMatch.Headers[header] = asString(m["exact"])
+		}
+	}
+
+	return httpMatch
+}
+
+func durationFromString(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func asSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+	return s
+}
+
+func asString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func asFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	default:
+		return 0
+	}
+}
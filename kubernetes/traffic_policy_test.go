@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWeightsFromRoute(t *testing.T) {
+	tests := []struct {
+		name               string
+		destinationWeights []interface{}
+		want               map[string]int
+	}{
+		{
+			name: "single unweighted destination gets all the traffic",
+			destinationWeights: []interface{}{
+				map[string]interface{}{
+					"destination": map[string]interface{}{"labels": map[string]interface{}{"version": "v1"}},
+				},
+			},
+			want: map[string]int{"v1": 100},
+		},
+		{
+			name: "explicit weights are kept as-is",
+			destinationWeights: []interface{}{
+				map[string]interface{}{
+					"destination": map[string]interface{}{"labels": map[string]interface{}{"version": "v1"}},
+					"weight":      float64(75),
+				},
+				map[string]interface{}{
+					"destination": map[string]interface{}{"labels": map[string]interface{}{"version": "v2"}},
+					"weight":      float64(25),
+				},
+			},
+			want: map[string]int{"v1": 75, "v2": 25},
+		},
+		{
+			name: "duplicate subsets are summed",
+			destinationWeights: []interface{}{
+				map[string]interface{}{
+					"destination": map[string]interface{}{"subset": "v1"},
+					"weight":      float64(30),
+				},
+				map[string]interface{}{
+					"destination": map[string]interface{}{"subset": "v1"},
+					"weight":      float64(20),
+				},
+			},
+			want: map[string]int{"v1": 50},
+		},
+		{
+			name:               "no destinations",
+			destinationWeights: nil,
+			want:               map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := weightsFromRoute(tt.destinationWeights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("weightsFromRoute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubsetFromDestinationWeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry map[string]interface{}
+		want  string
+	}{
+		{
+			name:  "RouteRule style destination.labels.version",
+			entry: map[string]interface{}{"destination": map[string]interface{}{"labels": map[string]interface{}{"version": "v1"}}},
+			want:  "v1",
+		},
+		{
+			name:  "VirtualService style destination.subset",
+			entry: map[string]interface{}{"destination": map[string]interface{}{"subset": "v2"}},
+			want:  "v2",
+		},
+		{
+			name:  "labels.version without a destination wrapper",
+			entry: map[string]interface{}{"labels": map[string]interface{}{"version": "v3"}},
+			want:  "v3",
+		},
+		{
+			name:  "no recognizable subset falls back to unknown",
+			entry: map[string]interface{}{},
+			want:  "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subsetFromDestinationWeight(tt.entry)
+			if got != tt.want {
+				t.Errorf("subsetFromDestinationWeight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
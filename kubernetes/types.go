@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"k8s.io/api/apps/v1beta1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/core/v1"
+)
+
+// ServiceList holds a list of services along with the resources backing them
+// (pods, deployments, generic workloads, autoscalers), as returned by
+// GetServices for a namespace.
+type ServiceList struct {
+	Services    *v1.ServiceList
+	Pods        *v1.PodList
+	Deployments *v1beta1.DeploymentList
+	Autoscalers *autoscalingV1.HorizontalPodAutoscalerList
+	Workloads   *WorkloadList
+}
+
+// ServiceDetails is the full set of Kubernetes objects that make up a single
+// service, as returned by GetServiceDetails.
+type ServiceDetails struct {
+	Service     *v1.Service
+	Endpoints   *v1.Endpoints
+	Autoscalers *autoscalingV1.HorizontalPodAutoscalerList
+	Deployments *v1beta1.DeploymentList
+	Pods        *v1.PodList
+	Workloads   *WorkloadList
+}
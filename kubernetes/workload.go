@@ -0,0 +1,242 @@
+package kubernetes
+
+import (
+	"k8s.io/api/apps/v1beta1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// WorkloadKind identifies the controller kind backing a Workload.
+type WorkloadKind string
+
+const (
+	WorkloadKindDeployment  WorkloadKind = "Deployment"
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+	WorkloadKindDaemonSet   WorkloadKind = "DaemonSet"
+	WorkloadKindReplicaSet  WorkloadKind = "ReplicaSet"
+)
+
+// Workload is a controller-agnostic view over Deployments, StatefulSets,
+// DaemonSets and bare ReplicaSets, so that graph appenders can attach
+// badges without caring which kind of controller backs a service.
+type Workload struct {
+	Kind     WorkloadKind
+	Name     string
+	Labels   map[string]string
+	Selector map[string]string
+	Replicas int32
+	Status   DeploymentStatus
+}
+
+// WorkloadList is the workload equivalent of v1beta1.DeploymentList, gathering
+// every controller kind kiali knows how to back a service with.
+type WorkloadList struct {
+	Items []Workload
+}
+
+type workloadsResponse struct {
+	workloads *WorkloadList
+	err       error
+}
+
+// GetWorkloads returns every Deployment, StatefulSet, DaemonSet and bare
+// ReplicaSet in the namespace, normalized into the generic Workload shape.
+func (in *IstioClient) GetWorkloads(namespace string) (*WorkloadList, error) {
+	workloadsChan := make(chan workloadsResponse)
+	go in.getWorkloads(namespace, nil, workloadsChan)
+	response := <-workloadsChan
+	return response.workloads, response.err
+}
+
+// getWorkloads gathers every controller kind into the generic Workload shape.
+// When deployments is non-nil the caller already listed Deployments for this
+// namespace (e.g. GetServiceDetails' legacy ServiceDetails.Deployments field)
+// and getWorkloads reuses it instead of issuing a redundant List call.
+func (in *IstioClient) getWorkloads(namespace string, deployments *v1beta1.DeploymentList, workloadsChan chan workloadsResponse) {
+	type statefulSetsResponse struct {
+		statefulSets *v1beta1.StatefulSetList
+		err          error
+	}
+	type daemonSetsResponse struct {
+		daemonSets *extensionsv1beta1.DaemonSetList
+		err        error
+	}
+	type replicaSetsResponse struct {
+		replicaSets *extensionsv1beta1.ReplicaSetList
+		err         error
+	}
+
+	var deploymentsChan chan deploymentsResponse
+	if deployments == nil {
+		deploymentsChan = make(chan deploymentsResponse)
+		go in.getDeployments(namespace, deploymentsChan)
+	}
+	statefulSetsChan := make(chan statefulSetsResponse)
+	daemonSetsChan := make(chan daemonSetsResponse)
+	replicaSetsChan := make(chan replicaSetsResponse)
+
+	go func() {
+		statefulSets, err := in.k8s.AppsV1beta1().StatefulSets(namespace).List(emptyListOptions)
+		statefulSetsChan <- statefulSetsResponse{statefulSets: statefulSets, err: err}
+	}()
+	go func() {
+		daemonSets, err := in.k8s.ExtensionsV1beta1().DaemonSets(namespace).List(emptyListOptions)
+		daemonSetsChan <- daemonSetsResponse{daemonSets: daemonSets, err: err}
+	}()
+	go func() {
+		replicaSets, err := in.k8s.ExtensionsV1beta1().ReplicaSets(namespace).List(emptyListOptions)
+		replicaSetsChan <- replicaSetsResponse{replicaSets: replicaSets, err: err}
+	}()
+
+	var deploymentsErr error
+	if deploymentsChan != nil {
+		deploymentsResponse := <-deploymentsChan
+		deploymentsErr = deploymentsResponse.err
+		deployments = deploymentsResponse.deployments
+	}
+
+	// Always drain statefulSetsChan/daemonSetsChan/replicaSetsChan, even if
+	// deployments failed above, so their goroutines aren't left blocked
+	// forever trying to send on a channel nobody reads from again.
+	statefulSets := <-statefulSetsChan
+	daemonSets := <-daemonSetsChan
+	replicaSets := <-replicaSetsChan
+
+	if deploymentsErr != nil {
+		workloadsChan <- workloadsResponse{err: deploymentsErr}
+		return
+	}
+	if statefulSets.err != nil {
+		workloadsChan <- workloadsResponse{err: statefulSets.err}
+		return
+	}
+	if daemonSets.err != nil {
+		workloadsChan <- workloadsResponse{err: daemonSets.err}
+		return
+	}
+	if replicaSets.err != nil {
+		workloadsChan <- workloadsResponse{err: replicaSets.err}
+		return
+	}
+
+	workloads := make([]Workload, 0)
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, workloadFromDeployment(deployment))
+	}
+	for _, statefulSet := range statefulSets.statefulSets.Items {
+		workloads = append(workloads, workloadFromStatefulSet(statefulSet))
+	}
+	for _, daemonSet := range daemonSets.daemonSets.Items {
+		workloads = append(workloads, workloadFromDaemonSet(daemonSet))
+	}
+	for _, replicaSet := range replicaSets.replicaSets.Items {
+		workloads = append(workloads, workloadFromReplicaSet(replicaSet))
+	}
+
+	workloadsChan <- workloadsResponse{workloads: &WorkloadList{Items: workloads}}
+}
+
+func workloadFromDeployment(deployment v1beta1.Deployment) Workload {
+	desiredReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+
+	return Workload{
+		Kind:     WorkloadKindDeployment,
+		Name:     deployment.Name,
+		Labels:   deployment.Labels,
+		Selector: selectorLabels(deployment.Spec.Selector),
+		Replicas: desiredReplicas,
+		Status:   GetDeploymentStatus(deployment),
+	}
+}
+
+func workloadFromStatefulSet(statefulSet v1beta1.StatefulSet) Workload {
+	desiredReplicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		desiredReplicas = *statefulSet.Spec.Replicas
+	}
+
+	rolloutStatus := RolloutStatusProgressing
+	if statefulSet.Status.ReadyReplicas == desiredReplicas && statefulSet.Status.ObservedGeneration >= statefulSet.Generation {
+		rolloutStatus = RolloutStatusReady
+	}
+
+	return Workload{
+		Kind:     WorkloadKindStatefulSet,
+		Name:     statefulSet.Name,
+		Labels:   statefulSet.Labels,
+		Selector: selectorLabels(statefulSet.Spec.Selector),
+		Replicas: desiredReplicas,
+		Status: DeploymentStatus{
+			Name:            statefulSet.Name,
+			ReadyReplicas:   statefulSet.Status.ReadyReplicas,
+			DesiredReplicas: desiredReplicas,
+			RolloutStatus:   rolloutStatus,
+		},
+	}
+}
+
+func workloadFromDaemonSet(daemonSet extensionsv1beta1.DaemonSet) Workload {
+	rolloutStatus := RolloutStatusProgressing
+	if daemonSet.Status.NumberReady == daemonSet.Status.DesiredNumberScheduled && daemonSet.Status.ObservedGeneration >= daemonSet.Generation {
+		rolloutStatus = RolloutStatusReady
+	}
+
+	return Workload{
+		Kind:     WorkloadKindDaemonSet,
+		Name:     daemonSet.Name,
+		Labels:   daemonSet.Labels,
+		Selector: selectorLabels(daemonSet.Spec.Selector),
+		Replicas: daemonSet.Status.DesiredNumberScheduled,
+		Status: DeploymentStatus{
+			Name:            daemonSet.Name,
+			ReadyReplicas:   daemonSet.Status.NumberReady,
+			DesiredReplicas: daemonSet.Status.DesiredNumberScheduled,
+			RolloutStatus:   rolloutStatus,
+		},
+	}
+}
+
+func workloadFromReplicaSet(replicaSet extensionsv1beta1.ReplicaSet) Workload {
+	desiredReplicas := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		desiredReplicas = *replicaSet.Spec.Replicas
+	}
+
+	rolloutStatus := RolloutStatusProgressing
+	if replicaSet.Status.ReadyReplicas == desiredReplicas && replicaSet.Status.ObservedGeneration >= replicaSet.Generation {
+		rolloutStatus = RolloutStatusReady
+	}
+
+	return Workload{
+		Kind:     WorkloadKindReplicaSet,
+		Name:     replicaSet.Name,
+		Labels:   replicaSet.Labels,
+		Selector: selectorLabels(replicaSet.Spec.Selector),
+		Replicas: desiredReplicas,
+		Status: DeploymentStatus{
+			Name:            replicaSet.Name,
+			ReadyReplicas:   replicaSet.Status.ReadyReplicas,
+			DesiredReplicas: desiredReplicas,
+			RolloutStatus:   rolloutStatus,
+		},
+	}
+}
+
+func selectorLabels(selector *meta_v1.LabelSelector) map[string]string {
+	if selector == nil {
+		return nil
+	}
+	return selector.MatchLabels
+}
+
+// matchesSelector reports whether podLabels satisfies the given workload selector.
+func matchesSelector(workloadSelector map[string]string, podLabels labels.Set) bool {
+	if len(workloadSelector) == 0 {
+		return false
+	}
+	return labels.Set(workloadSelector).AsSelector().Matches(podLabels)
+}
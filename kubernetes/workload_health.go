@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"k8s.io/api/apps/v1beta1"
+	"k8s.io/api/core/v1"
+)
+
+// RolloutStatus represents the high level health of a Deployment's rollout,
+// derived from the same signals `kubectl rollout status` relies on.
+type RolloutStatus string
+
+const (
+	RolloutStatusReady       RolloutStatus = "Ready"
+	RolloutStatusProgressing RolloutStatus = "Progressing"
+	RolloutStatusDegraded    RolloutStatus = "Degraded"
+	RolloutStatusPaused      RolloutStatus = "Paused"
+)
+
+// maxPodRestartsThreshold is the number of container restarts above which a pod
+// is considered unhealthy even when its conditions report ready.
+const maxPodRestartsThreshold = 5
+
+// DeploymentStatus summarizes a Deployment's rollout health for graph badges.
+type DeploymentStatus struct {
+	Name            string
+	ReadyReplicas   int32
+	DesiredReplicas int32
+	RolloutStatus   RolloutStatus
+}
+
+// GetDeploymentStatus computes the rollout health of a Deployment by comparing its
+// Status against its Spec, following the same rules as `kubectl rollout status`.
+func GetDeploymentStatus(deployment v1beta1.Deployment) DeploymentStatus {
+	spec := deployment.Spec
+	status := deployment.Status
+
+	desiredReplicas := int32(1)
+	if spec.Replicas != nil {
+		desiredReplicas = *spec.Replicas
+	}
+
+	return DeploymentStatus{
+		Name:            deployment.Name,
+		ReadyReplicas:   status.ReadyReplicas,
+		DesiredReplicas: desiredReplicas,
+		RolloutStatus:   rolloutStatusOf(deployment, desiredReplicas),
+	}
+}
+
+func rolloutStatusOf(deployment v1beta1.Deployment, desiredReplicas int32) RolloutStatus {
+	spec := deployment.Spec
+	status := deployment.Status
+
+	if status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == desiredReplicas &&
+		status.AvailableReplicas == desiredReplicas &&
+		status.ReadyReplicas == desiredReplicas {
+		return RolloutStatusReady
+	}
+
+	if spec.Paused {
+		return RolloutStatusPaused
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type != v1beta1.DeploymentProgressing {
+			continue
+		}
+		if condition.Status == v1.ConditionFalse || condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatusDegraded
+		}
+		if condition.Status == v1.ConditionTrue && condition.Reason != "ProgressDeadlineExceeded" {
+			return RolloutStatusProgressing
+		}
+	}
+
+	return RolloutStatusProgressing
+}
+
+// IsPodHealthy reports whether a pod is ready, has all of its containers ready,
+// and isn't crash-looping or restarting excessively.
+func IsPodHealthy(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != v1.PodReady && condition.Type != v1.ContainersReady {
+			continue
+		}
+		if condition.Status != v1.ConditionTrue {
+			return false
+		}
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.RestartCount > maxPodRestartsThreshold {
+			return false
+		}
+		if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasUnhealthyPods reports whether any pod in the list fails the IsPodHealthy check.
+func HasUnhealthyPods(pods []v1.Pod) bool {
+	for _, pod := range pods {
+		if !IsPodHealthy(pod) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"k8s.io/api/apps/v1beta1"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRolloutStatusOf(t *testing.T) {
+	replicas := int32(3)
+
+	tests := []struct {
+		name       string
+		deployment v1beta1.Deployment
+		want       RolloutStatus
+	}{
+		{
+			name: "fully rolled out",
+			deployment: v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Generation: 1},
+				Spec:       v1beta1.DeploymentSpec{Replicas: &replicas},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    replicas,
+					AvailableReplicas:  replicas,
+					ReadyReplicas:      replicas,
+				},
+			},
+			want: RolloutStatusReady,
+		},
+		{
+			name: "fully rolled out but paused reports Ready, not Paused",
+			deployment: v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Generation: 1},
+				Spec:       v1beta1.DeploymentSpec{Replicas: &replicas, Paused: true},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    replicas,
+					AvailableReplicas:  replicas,
+					ReadyReplicas:      replicas,
+				},
+			},
+			want: RolloutStatusReady,
+		},
+		{
+			name: "paused mid-rollout reports Paused",
+			deployment: v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Generation: 1},
+				Spec:       v1beta1.DeploymentSpec{Replicas: &replicas, Paused: true},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+					ReadyReplicas:      1,
+				},
+			},
+			want: RolloutStatusPaused,
+		},
+		{
+			name: "progress deadline exceeded reports Degraded",
+			deployment: v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Generation: 1},
+				Spec:       v1beta1.DeploymentSpec{Replicas: &replicas},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    1,
+					Conditions: []v1beta1.DeploymentCondition{
+						{Type: v1beta1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+					},
+				},
+			},
+			want: RolloutStatusDegraded,
+		},
+		{
+			name: "still updating reports Progressing",
+			deployment: v1beta1.Deployment{
+				ObjectMeta: meta_v1.ObjectMeta{Generation: 1},
+				Spec:       v1beta1.DeploymentSpec{Replicas: &replicas},
+				Status: v1beta1.DeploymentStatus{
+					ObservedGeneration: 1,
+					UpdatedReplicas:    1,
+					Conditions: []v1beta1.DeploymentCondition{
+						{Type: v1beta1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+					},
+				},
+			},
+			want: RolloutStatusProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rolloutStatusOf(tt.deployment, replicas)
+			if got != tt.want {
+				t.Errorf("rolloutStatusOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}